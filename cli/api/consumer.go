@@ -0,0 +1,132 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/sequinstream/sequin/cli/context"
+)
+
+// ConsumerMessage is a message delivered to a Sequin Stream consumer
+type ConsumerMessage struct {
+	AckID string                 `json:"ack_id"`
+	Data  map[string]interface{} `json:"data"`
+}
+
+type ReceiveResponse struct {
+	Data []ConsumerMessage `json:"data"`
+}
+
+// BuildReceiveMessages builds the HTTP request for receiving messages for a consumer
+func BuildReceiveMessages(ctx *context.Context, consumer string, batchSize int, waitFor string) (*http.Request, error) {
+	serverURL, err := context.GetServerURL(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := url.Values{}
+	query.Set("max_batch_size", fmt.Sprintf("%d", batchSize))
+	if waitFor != "" {
+		query.Set("wait_for", waitFor)
+	}
+
+	reqURL := fmt.Sprintf("%s/api/sequin_streams/%s/receive?%s", serverURL, url.PathEscape(consumer), query.Encode())
+	req, err := http.NewRequest("GET", reqURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating request: %w", err)
+	}
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", ctx.ApiToken))
+
+	return req, nil
+}
+
+// ReceiveMessages pulls the next batch of messages for a consumer
+func ReceiveMessages(ctx *context.Context, consumer string, batchSize int, waitFor string) ([]ConsumerMessage, error) {
+	req, err := BuildReceiveMessages(ctx, consumer, batchSize, waitFor)
+	if err != nil {
+		return nil, fmt.Errorf("error building receive messages request: %w", err)
+	}
+
+	body, err := doRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	var receiveResponse ReceiveResponse
+	if err := json.Unmarshal(body, &receiveResponse); err != nil {
+		return nil, fmt.Errorf("error unmarshaling JSON: %w", err)
+	}
+
+	return receiveResponse.Data, nil
+}
+
+// BuildAckMessages builds the HTTP request for acking or nacking messages.
+// action is either "ack" or "nack".
+func BuildAckMessages(ctx *context.Context, consumer string, action string, ackIDs []string) (*http.Request, error) {
+	serverURL, err := context.GetServerURL(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	jsonBody, err := json.Marshal(map[string][]string{"ack_ids": ackIDs})
+	if err != nil {
+		return nil, fmt.Errorf("error marshaling JSON: %w", err)
+	}
+
+	reqURL := fmt.Sprintf("%s/api/sequin_streams/%s/%s", serverURL, url.PathEscape(consumer), action)
+	req, err := http.NewRequest("POST", reqURL, bytes.NewBuffer(jsonBody))
+	if err != nil {
+		return nil, fmt.Errorf("error creating request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", ctx.ApiToken))
+
+	return req, nil
+}
+
+// AckMessages acknowledges messages so they are not redelivered
+func AckMessages(ctx *context.Context, consumer string, ackIDs []string) error {
+	return ackOrNack(ctx, consumer, "ack", ackIDs)
+}
+
+// NackMessages makes messages immediately available for redelivery
+func NackMessages(ctx *context.Context, consumer string, ackIDs []string) error {
+	return ackOrNack(ctx, consumer, "nack", ackIDs)
+}
+
+func ackOrNack(ctx *context.Context, consumer string, action string, ackIDs []string) error {
+	req, err := BuildAckMessages(ctx, consumer, action, ackIDs)
+	if err != nil {
+		return fmt.Errorf("error building %s request: %w", action, err)
+	}
+
+	_, err = doRequest(req)
+	return err
+}
+
+func doRequest(req *http.Request) ([]byte, error) {
+	client := &http.Client{}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error making request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error reading response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		if apiErr := ParseAPIError(resp.StatusCode, string(body)); apiErr != nil {
+			return nil, apiErr
+		}
+		return nil, NewAPIError(resp.StatusCode, string(body))
+	}
+
+	return body, nil
+}
@@ -59,22 +59,29 @@ func (c *ConfigCommands) applyAction(_ *fisk.ParseContext) error {
 		return nil
 	}
 
-	// Ask for confirmation
-	fmt.Print("\nDo you want to apply these changes? Only 'yes' will be accepted to confirm: ")
+	ctx, err := context.LoadContext(c.config.ContextName)
+	if err != nil {
+		return fmt.Errorf("failed to load context: %w", err)
+	}
+
+	// Ask for confirmation. Production contexts require typing the context name.
+	confirmation := "yes"
+	if ctx.Production {
+		// planAction has already printed the production banner
+		confirmation = ctx.Name
+		fmt.Printf("\nDo you want to apply these changes? Type the context name '%s' to confirm: ", confirmation)
+	} else {
+		fmt.Print("\nDo you want to apply these changes? Only 'yes' will be accepted to confirm: ")
+	}
 	var response string
 	fmt.Scanln(&response)
 
-	if response != "yes" {
+	if response != confirmation {
 		fmt.Println("Apply cancelled.")
 		return nil
 	}
 
 	// Call apply
-	ctx, err := context.LoadContext(c.config.ContextName)
-	if err != nil {
-		return fmt.Errorf("failed to load context: %w", err)
-	}
-
 	applyResp, err := config.Apply(ctx, c.yamlPath)
 	if err != nil {
 		return err
@@ -93,6 +100,10 @@ func (c *ConfigCommands) planAction(_ *fisk.ParseContext) error {
 		return fmt.Errorf("failed to load context: %w", err)
 	}
 
+	if ctx.Production {
+		printProductionBanner(ctx)
+	}
+
 	// Call plan
 	planResp, err := config.Plan(ctx, c.yamlPath)
 	if err != nil {
@@ -165,6 +176,12 @@ func (c *ConfigCommands) planAction(_ *fisk.ParseContext) error {
 	return nil
 }
 
+// printProductionBanner warns that commands are running against a production context
+func printProductionBanner(ctx *context.Context) {
+	banner := color.New(color.FgWhite, color.BgRed, color.Bold).Sprint(" PRODUCTION ")
+	fmt.Printf("%s Context '%s' is marked as production.\n\n", banner, ctx.Name)
+}
+
 // Helper function to convert struct to map
 func convertToMap(v interface{}) map[string]interface{} {
 	result := make(map[string]interface{})
@@ -0,0 +1,146 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/choria-io/fisk"
+	"github.com/fatih/color"
+
+	"github.com/sequinstream/sequin/cli/api"
+	"github.com/sequinstream/sequin/cli/context"
+)
+
+type consumerReceiveCommand struct {
+	consumer  string
+	batchSize int
+	waitFor   string
+	ack       bool
+}
+
+// AddConsumerCommands adds the 'consumer' commands for Sequin Stream consumers
+func AddConsumerCommands(app *fisk.Application, config *Config) {
+	cmd := &consumerReceiveCommand{}
+	consumer := app.Command("consumer", "Sequin Stream consumer commands").Alias("con")
+
+	// Add cheats
+	addCheat("consumer", consumer)
+
+	receive := consumer.Command("receive", "Pull the next batch of messages and ack or nack each one").Action(func(_ *fisk.ParseContext) error {
+		return cmd.receiveAction(config)
+	})
+	receive.Arg("consumer", "Name or ID of the consumer").StringVar(&cmd.consumer)
+	receive.Flag("batch-size", "Maximum number of messages to receive (1-1000)").Default("1").IntVar(&cmd.batchSize)
+	receive.Flag("wait-for", "How long to wait for messages to become available, e.g. 5s").StringVar(&cmd.waitFor)
+	receive.Flag("ack", "Acknowledge all received messages without prompting").BoolVar(&cmd.ack)
+}
+
+func (c *consumerReceiveCommand) receiveAction(config *Config) error {
+	if c.batchSize < 1 || c.batchSize > 1000 {
+		return fmt.Errorf("--batch-size must be between 1 and 1000, got %d", c.batchSize)
+	}
+
+	ctx, err := context.LoadContext(config.ContextName)
+	if err != nil {
+		return fmt.Errorf("failed to load context: %w", err)
+	}
+
+	if ctx.Production {
+		printProductionBanner(ctx)
+
+		// Acking a whole batch unseen is destructive, so require the context name
+		if c.ack {
+			fmt.Printf("Type the context name '%s' to ack every received message: ", ctx.Name)
+			var response string
+			fmt.Scanln(&response)
+
+			if response != ctx.Name {
+				fmt.Println("Receive cancelled.")
+				return nil
+			}
+			fmt.Println()
+		}
+	}
+
+	if c.consumer == "" {
+		prompt := &survey.Input{
+			Message: "Enter the consumer name or ID:",
+		}
+		err := survey.AskOne(prompt, &c.consumer, survey.WithValidator(survey.Required))
+		if err != nil {
+			return fmt.Errorf("failed to get consumer: %w", err)
+		}
+	}
+
+	messages, err := api.ReceiveMessages(ctx, c.consumer, c.batchSize, c.waitFor)
+	if err != nil {
+		return fmt.Errorf("failed to receive messages: %w", err)
+	}
+
+	if len(messages) == 0 {
+		fmt.Println("No messages available.")
+		return nil
+	}
+
+	fmt.Printf("Received %d message(s) for consumer '%s'\n\n", len(messages), c.consumer)
+
+	bold := color.New(color.Bold).SprintFunc()
+	acked, nacked := 0, 0
+
+	for i, msg := range messages {
+		fmt.Printf("%s %s\n", bold(fmt.Sprintf("[%d/%d] Ack ID:", i+1, len(messages))), msg.AckID)
+
+		data, err := json.MarshalIndent(msg.Data, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to format message data: %w", err)
+		}
+		fmt.Printf("%s\n\n", data)
+
+		if c.ack {
+			continue
+		}
+
+		var choice string
+		prompt := &survey.Select{
+			Message: "What do you want to do with this message?",
+			Options: []string{"Ack", "Nack", "Skip"},
+			Default: "Skip",
+		}
+		if err := survey.AskOne(prompt, &choice); err != nil {
+			return fmt.Errorf("failed to get user input: %w", err)
+		}
+
+		// Send each choice right away, so earlier choices survive an aborted
+		// prompt and ack IDs don't go stale while later messages are reviewed
+		switch choice {
+		case "Ack":
+			if err := api.AckMessages(ctx, c.consumer, []string{msg.AckID}); err != nil {
+				return fmt.Errorf("failed to ack message: %w", err)
+			}
+			acked++
+		case "Nack":
+			if err := api.NackMessages(ctx, c.consumer, []string{msg.AckID}); err != nil {
+				return fmt.Errorf("failed to nack message: %w", err)
+			}
+			nacked++
+		}
+	}
+
+	if c.ack {
+		ackIDs := make([]string, len(messages))
+		for i, msg := range messages {
+			ackIDs[i] = msg.AckID
+		}
+		if err := api.AckMessages(ctx, c.consumer, ackIDs); err != nil {
+			return fmt.Errorf("failed to ack messages: %w", err)
+		}
+		acked = len(ackIDs)
+	}
+
+	skipped := len(messages) - acked - nacked
+	fmt.Printf("Acked %d, nacked %d, skipped %d. Skipped messages are redelivered once their visibility timeout expires.\n",
+		acked, nacked, skipped)
+
+	return nil
+}
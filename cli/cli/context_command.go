@@ -2,6 +2,7 @@ package cli
 
 import (
 	"fmt"
+	"os"
 	"strings"
 
 	"github.com/AlecAivazis/survey/v2"
@@ -22,6 +23,8 @@ type ctxCommand struct {
 	apiToken      string
 	tunnelPorts   string // New field for tunnel ports
 	force         bool   // New field for force edit
+	production    bool
+	productionSet bool
 }
 
 func AddContextCommands(app *fisk.Application, _config *Config) {
@@ -44,6 +47,8 @@ func AddContextCommands(app *fisk.Application, _config *Config) {
 		StringVar(&cmd.apiToken)
 	add.Flag("tunnel-ports", "Comma-separated list of tunnel ports in the format port:nameOrId").
 		StringVar(&cmd.tunnelPorts)
+	add.Flag("production", "Mark this context as production, requiring extra confirmation for destructive actions").
+		BoolVar(&cmd.production)
 
 	ctx.Command("ls", "List all contexts").Action(cmd.listAction)
 
@@ -56,6 +61,8 @@ func AddContextCommands(app *fisk.Application, _config *Config) {
 	selectCmd := ctx.Command("select", "Select a default context").Action(cmd.selectAction)
 	selectCmd.Arg("name", "The context name").StringVar(&cmd.name)
 
+	ctx.Command("encrypt", "Encrypt stored contexts with a passphrase").Action(cmd.encryptAction)
+
 	edit := ctx.Command("edit", "Edit an existing context").Action(cmd.editAction)
 	edit.Arg("name", "The context name to edit").StringVar(&cmd.name)
 	edit.Flag("hostname", "The API hostname for this context").StringVar(&cmd.hostname)
@@ -63,6 +70,7 @@ func AddContextCommands(app *fisk.Application, _config *Config) {
 	edit.Flag("tls", "Enable TLS for this context").BoolVar(&cmd.tls)
 	edit.Flag("api-token", "The API Token for this context").StringVar(&cmd.apiToken)
 	edit.Flag("tunnel-ports", "Comma-separated list of tunnel ports in the format port:nameOrId").StringVar(&cmd.tunnelPorts)
+	edit.Flag("production", "Mark this context as production, requiring extra confirmation for destructive actions").IsSetByUser(&cmd.productionSet).BoolVar(&cmd.production)
 	edit.Flag("force", "Force edit without confirmation").BoolVar(&cmd.force)
 }
 
@@ -94,6 +102,7 @@ func (c *ctxCommand) addAction(pctx *fisk.ParseContext) error {
 		Hostname:      c.hostname,
 		TLS:           c.tls,
 		PortalBaseURL: c.portalBaseURL,
+		Production:    c.production,
 	}
 
 	// Parse and add tunnel ports if provided
@@ -202,6 +211,7 @@ func (c *ctxCommand) infoAction(_ *fisk.ParseContext) error {
 		{"TLS", fmt.Sprintf("%t", ctx.TLS)},
 		{"Portal Base URL", ctx.PortalBaseURL},
 		{"Default", fmt.Sprintf("%t", ctx.Default)},
+		{"Production", fmt.Sprintf("%t", ctx.Production)},
 		{"API Token", strings.Repeat("*", len(ctx.ApiToken))},
 	}
 
@@ -366,6 +376,9 @@ func (c *ctxCommand) editAction(_ *fisk.ParseContext) error {
 		}
 		newCtx.TunnelPorts = tunnelPorts
 	}
+	if c.productionSet {
+		newCtx.Production = c.production
+	}
 
 	// Compare the configurations
 	diff := cmp.Diff(existingCtx, &newCtx)
@@ -394,3 +407,49 @@ func (c *ctxCommand) editAction(_ *fisk.ParseContext) error {
 	fmt.Printf("Context '%s' updated successfully.\n", c.name)
 	return nil
 }
+
+func (c *ctxCommand) encryptAction(_ *fisk.ParseContext) error {
+	passphrase := os.Getenv(context.PassphraseEnvVar)
+	if passphrase == "" {
+		answers := struct {
+			Passphrase string
+			Confirm    string
+		}{}
+		err := survey.Ask([]*survey.Question{
+			{
+				Name:     "passphrase",
+				Prompt:   &survey.Password{Message: "Enter a passphrase to encrypt contexts with:"},
+				Validate: survey.Required,
+			},
+			{
+				Name:   "confirm",
+				Prompt: &survey.Password{Message: "Confirm the passphrase:"},
+			},
+		}, &answers)
+		if err != nil {
+			return fmt.Errorf("failed to get passphrase: %w", err)
+		}
+		if answers.Passphrase != answers.Confirm {
+			return fmt.Errorf("passphrases do not match")
+		}
+		passphrase = answers.Passphrase
+	}
+
+	count, err := context.EncryptContexts(passphrase)
+	if err != nil {
+		return fmt.Errorf("could not encrypt contexts: %w", err)
+	}
+
+	fmt.Print(text.FgGreen.Sprintf("Encrypted %d context(s).\n", count))
+	fmt.Printf("Set %s or enter the passphrase when prompted to use them.\n", context.PassphraseEnvVar)
+	return nil
+}
+
+// PromptContextPassphrase asks for the passphrase of an encrypted context
+func PromptContextPassphrase() (string, error) {
+	var passphrase string
+	err := survey.AskOne(&survey.Password{
+		Message: "Enter the context passphrase:",
+	}, &passphrase)
+	return passphrase, err
+}
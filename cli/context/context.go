@@ -18,6 +18,7 @@ type Context struct {
 	Default       bool                `json:"default"`
 	ApiToken      string              `json:"api_token"`
 	TunnelPorts   []map[string]string `json:"tunnelPorts,omitempty"`
+	Production    bool                `json:"production,omitempty"`
 }
 
 var defaultContext = Context{
@@ -60,7 +61,12 @@ func SaveContext(ctx Context) error {
 		return fmt.Errorf("could not marshal context: %w", err)
 	}
 
-	err = os.WriteFile(file, data, 0644)
+	data, err = encodeContextFile(dir, data)
+	if err != nil {
+		return fmt.Errorf("could not encrypt context: %w", err)
+	}
+
+	err = writeFileAtomic(file, data)
 	if err != nil {
 		return fmt.Errorf("could not write context file: %w", err)
 	}
@@ -92,6 +98,11 @@ func LoadContext(name string) (*Context, error) {
 		return nil, fmt.Errorf("could not read context file: %w", err)
 	}
 
+	data, err = decodeContextFile(data)
+	if err != nil {
+		return nil, err
+	}
+
 	var ctx Context
 	err = json.Unmarshal(data, &ctx)
 	if err != nil {
@@ -147,6 +158,87 @@ func ListContexts() ([]Context, error) {
 	return contexts, nil
 }
 
+// EncryptContexts encrypts every plaintext context file in place with the
+// given passphrase and returns how many files were encrypted. Nothing is
+// written unless every already-encrypted file decrypts with the passphrase.
+func EncryptContexts(passphrase string) (int, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return 0, fmt.Errorf("could not get user home directory: %w", err)
+	}
+
+	dir := filepath.Join(home, ".sequin", "contexts")
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		return 0, fmt.Errorf("could not read contexts directory: %w", err)
+	}
+
+	// Check the whole store before writing, so it ends up under one passphrase
+	plaintexts := map[string][]byte{}
+	for _, file := range files {
+		if filepath.Ext(file.Name()) != ".json" {
+			continue
+		}
+
+		path := filepath.Join(dir, file.Name())
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return 0, fmt.Errorf("could not read context file %s: %w", file.Name(), err)
+		}
+
+		if isEncrypted(data) {
+			if _, err := decrypt(passphrase, data); err != nil {
+				return 0, fmt.Errorf("context %s is already encrypted with a different passphrase", file.Name())
+			}
+			continue
+		}
+
+		plaintexts[path] = data
+	}
+
+	cachedPassphrase = passphrase
+
+	count := 0
+	for path, data := range plaintexts {
+		encrypted, err := encrypt(passphrase, data)
+		if err != nil {
+			return count, fmt.Errorf("could not encrypt context %s: %w", filepath.Base(path), err)
+		}
+
+		err = writeFileAtomic(path, encrypted)
+		if err != nil {
+			return count, fmt.Errorf("could not write context file %s: %w", filepath.Base(path), err)
+		}
+		count++
+	}
+
+	return count, nil
+}
+
+// writeFileAtomic writes through a temp file and rename, so a crash can't
+// leave a half-written context file behind
+func writeFileAtomic(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Chmod(0644); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
+
 func RemoveContext(name string) error {
 	home, err := os.UserHomeDir()
 	if err != nil {
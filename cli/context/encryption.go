@@ -0,0 +1,217 @@
+package context
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"golang.org/x/crypto/scrypt"
+)
+
+// PassphraseEnvVar is the environment variable holding the passphrase used to
+// encrypt context files at rest
+const PassphraseEnvVar = "SEQUIN_CONTEXT_PASSPHRASE"
+
+// PromptPassphrase is called when an encrypted context file is read and
+// PassphraseEnvVar is not set. Left nil, reading encrypted contexts fails.
+var PromptPassphrase func() (string, error)
+
+// The passphrase is cached for the rest of the process once obtained, so
+// listing contexts only prompts once and re-saving a context keeps it encrypted.
+var cachedPassphrase string
+
+const (
+	scryptN      = 1 << 15
+	scryptR      = 8
+	scryptP      = 1
+	scryptKeyLen = 32
+	saltLen      = 16
+)
+
+// encryptedFile is the on-disk format of an encrypted context file
+type encryptedFile struct {
+	Encrypted  bool   `json:"encrypted"`
+	Salt       []byte `json:"salt"`
+	Nonce      []byte `json:"nonce"`
+	Ciphertext []byte `json:"ciphertext"`
+}
+
+func getPassphrase(required bool) (string, error) {
+	if cachedPassphrase != "" {
+		return cachedPassphrase, nil
+	}
+
+	if passphrase := os.Getenv(PassphraseEnvVar); passphrase != "" {
+		cachedPassphrase = passphrase
+		return passphrase, nil
+	}
+
+	if !required {
+		return "", nil
+	}
+
+	if PromptPassphrase == nil {
+		return "", fmt.Errorf("context is encrypted, set %s to decrypt it", PassphraseEnvVar)
+	}
+
+	passphrase, err := PromptPassphrase()
+	if err != nil {
+		return "", fmt.Errorf("could not get passphrase: %w", err)
+	}
+	if passphrase == "" {
+		return "", fmt.Errorf("passphrase cannot be empty")
+	}
+
+	cachedPassphrase = passphrase
+	return passphrase, nil
+}
+
+func isEncrypted(data []byte) bool {
+	var file encryptedFile
+	return json.Unmarshal(data, &file) == nil && file.Encrypted
+}
+
+// decodeContextFile returns the plaintext JSON of a context file. Plaintext
+// files are returned unchanged.
+func decodeContextFile(data []byte) ([]byte, error) {
+	if !isEncrypted(data) {
+		return data, nil
+	}
+
+	passphrase, err := getPassphrase(true)
+	if err != nil {
+		return nil, err
+	}
+
+	plaintext, err := decrypt(passphrase, data)
+	if err != nil {
+		cachedPassphrase = ""
+		return nil, err
+	}
+
+	return plaintext, nil
+}
+
+// encodeContextFile encrypts plaintext when the store in dir is encrypted or
+// a passphrase is set in the environment. A store counts as encrypted once any
+// of its files are, and the passphrase is checked against that file first so
+// a typo can't put part of the store under a different passphrase.
+func encodeContextFile(dir string, plaintext []byte) ([]byte, error) {
+	existing, err := findEncryptedContext(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	if existing != nil {
+		if _, err := decodeContextFile(existing); err != nil {
+			return nil, err
+		}
+	}
+
+	passphrase, err := getPassphrase(existing != nil)
+	if err != nil {
+		return nil, err
+	}
+	if passphrase == "" {
+		return plaintext, nil
+	}
+
+	return encrypt(passphrase, plaintext)
+}
+
+// findEncryptedContext returns the contents of an encrypted context file in
+// dir, or nil if the store is unencrypted
+func findEncryptedContext(dir string) ([]byte, error) {
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("could not read contexts directory: %w", err)
+	}
+
+	for _, file := range files {
+		if filepath.Ext(file.Name()) != ".json" {
+			continue
+		}
+
+		data, err := os.ReadFile(filepath.Join(dir, file.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("could not read context file %s: %w", file.Name(), err)
+		}
+		if isEncrypted(data) {
+			return data, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func decrypt(passphrase string, data []byte) ([]byte, error) {
+	var file encryptedFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("could not unmarshal encrypted context: %w", err)
+	}
+
+	gcm, err := newGCM(passphrase, file.Salt)
+	if err != nil {
+		return nil, err
+	}
+
+	plaintext, err := gcm.Open(nil, file.Nonce, file.Ciphertext, nil)
+	if err != nil {
+		return nil, errors.New("could not decrypt context, is the passphrase correct?")
+	}
+
+	return plaintext, nil
+}
+
+func encrypt(passphrase string, plaintext []byte) ([]byte, error) {
+	salt := make([]byte, saltLen)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, fmt.Errorf("could not generate salt: %w", err)
+	}
+
+	gcm, err := newGCM(passphrase, salt)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("could not generate nonce: %w", err)
+	}
+
+	file := encryptedFile{
+		Encrypted:  true,
+		Salt:       salt,
+		Nonce:      nonce,
+		Ciphertext: gcm.Seal(nil, nonce, plaintext, nil),
+	}
+
+	data, err := json.MarshalIndent(file, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("could not marshal encrypted context: %w", err)
+	}
+
+	return data, nil
+}
+
+func newGCM(passphrase string, salt []byte) (cipher.AEAD, error) {
+	key, err := scrypt.Key([]byte(passphrase), salt, scryptN, scryptR, scryptP, scryptKeyLen)
+	if err != nil {
+		return nil, fmt.Errorf("could not derive key: %w", err)
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("could not create cipher: %w", err)
+	}
+
+	return cipher.NewGCM(block)
+}
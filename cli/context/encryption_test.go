@@ -0,0 +1,161 @@
+package context
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// setupStore points HOME at a temp dir and clears any passphrase state
+func setupStore(t *testing.T) string {
+	t.Helper()
+
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv(PassphraseEnvVar, "")
+
+	cachedPassphrase = ""
+	PromptPassphrase = nil
+	t.Cleanup(func() { cachedPassphrase = "" })
+
+	dir := filepath.Join(home, ".sequin", "contexts")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	return dir
+}
+
+func TestEncryptDecodeRoundTrip(t *testing.T) {
+	setupStore(t)
+
+	plaintext := []byte(`{"name":"prod","apiToken":"secret"}`)
+	encrypted, err := encrypt("hunter2", plaintext)
+	if err != nil {
+		t.Fatalf("encrypt: %v", err)
+	}
+	if bytes.Contains(encrypted, []byte("secret")) {
+		t.Fatal("encrypted file contains the plaintext token")
+	}
+
+	cachedPassphrase = "hunter2"
+	decoded, err := decodeContextFile(encrypted)
+	if err != nil {
+		t.Fatalf("decodeContextFile: %v", err)
+	}
+	if !bytes.Equal(decoded, plaintext) {
+		t.Fatalf("got %s, want %s", decoded, plaintext)
+	}
+}
+
+func TestDecodeWrongPassphrase(t *testing.T) {
+	setupStore(t)
+
+	encrypted, err := encrypt("hunter2", []byte(`{"name":"prod"}`))
+	if err != nil {
+		t.Fatalf("encrypt: %v", err)
+	}
+
+	cachedPassphrase = "wrong"
+	if _, err := decodeContextFile(encrypted); err == nil {
+		t.Fatal("expected an error decrypting with the wrong passphrase")
+	}
+	if cachedPassphrase != "" {
+		t.Fatalf("cached passphrase was not cleared, got %q", cachedPassphrase)
+	}
+}
+
+func TestDecodePlaintextPassthrough(t *testing.T) {
+	setupStore(t)
+
+	plaintext := []byte(`{"name":"dev","apiToken":"token"}`)
+	decoded, err := decodeContextFile(plaintext)
+	if err != nil {
+		t.Fatalf("decodeContextFile: %v", err)
+	}
+	if !bytes.Equal(decoded, plaintext) {
+		t.Fatalf("got %s, want %s", decoded, plaintext)
+	}
+}
+
+func TestEncryptContextsPassphraseMismatch(t *testing.T) {
+	dir := setupStore(t)
+
+	plainPath := filepath.Join(dir, "a.json")
+	plaintext := []byte(`{"name":"a"}`)
+	if err := os.WriteFile(plainPath, plaintext, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	encrypted, err := encrypt("one", []byte(`{"name":"b"}`))
+	if err != nil {
+		t.Fatalf("encrypt: %v", err)
+	}
+	encryptedPath := filepath.Join(dir, "b.json")
+	if err := os.WriteFile(encryptedPath, encrypted, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := EncryptContexts("two"); err == nil {
+		t.Fatal("expected an error encrypting with a different passphrase")
+	}
+
+	data, err := os.ReadFile(plainPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(data, plaintext) {
+		t.Fatalf("a.json was modified, got %s", data)
+	}
+
+	data, err = os.ReadFile(encryptedPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := decrypt("one", data); err != nil {
+		t.Fatalf("b.json no longer decrypts with its passphrase: %v", err)
+	}
+
+	if cachedPassphrase != "" {
+		t.Fatalf("failed encrypt cached the passphrase %q", cachedPassphrase)
+	}
+}
+
+func TestSaveContextKeepsStoreEncrypted(t *testing.T) {
+	dir := setupStore(t)
+
+	if err := SaveContext(Context{Name: "a", Hostname: "localhost"}); err != nil {
+		t.Fatalf("SaveContext: %v", err)
+	}
+	if _, err := EncryptContexts("one"); err != nil {
+		t.Fatalf("EncryptContexts: %v", err)
+	}
+
+	// A new process with the wrong passphrase must not save under it
+	cachedPassphrase = ""
+	t.Setenv(PassphraseEnvVar, "two")
+	if err := SaveContext(Context{Name: "b", Hostname: "localhost"}); err == nil {
+		t.Fatal("expected an error saving with the wrong passphrase")
+	}
+	if _, err := os.Stat(filepath.Join(dir, "b.json")); !os.IsNotExist(err) {
+		t.Fatalf("b.json was written with the wrong passphrase")
+	}
+
+	// Without the env var, the store stays encrypted via the prompt
+	cachedPassphrase = ""
+	t.Setenv(PassphraseEnvVar, "")
+	PromptPassphrase = func() (string, error) { return "one", nil }
+	t.Cleanup(func() { PromptPassphrase = nil })
+
+	if err := SaveContext(Context{Name: "b", Hostname: "localhost"}); err != nil {
+		t.Fatalf("SaveContext: %v", err)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, "b.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := decrypt("one", data); err != nil {
+		t.Fatalf("b.json was not encrypted with the store passphrase: %v", err)
+	}
+}
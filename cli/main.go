@@ -8,9 +8,11 @@ import (
 	"runtime/debug"
 
 	"github.com/choria-io/fisk"
+	"golang.org/x/term"
 
 	"github.com/sequinstream/sequin/cli/cli"
 	"github.com/sequinstream/sequin/cli/constants"
+	"github.com/sequinstream/sequin/cli/context"
 )
 
 var (
@@ -36,6 +38,9 @@ func main() {
 
 	log.Println("Starting Sequin CLI version:", getVersion())
 
+	// Restore the terminal if a prompt or TUI panics while it owns the screen
+	defer recoverFromPanic(captureTerminalState())
+
 	help := `Sequin CLI
 
 See 'sequin cheat' for a quick cheatsheet of commands`
@@ -47,12 +52,16 @@ See 'sequin cheat' for a quick cheatsheet of commands`
 	scli.HelpFlag.Short('h')
 	scli.WithCheats().CheatCommand.Hidden()
 
+	// Prompt for the passphrase of encrypted contexts when it isn't in the environment
+	context.PromptPassphrase = cli.PromptContextPassphrase
+
 	// Add global context flag
 	scli.Flag("context", "Use a specific context").StringVar(&config.ContextName)
 
 	cli.AddContextCommands(scli, &config)
 	cli.AddTunnelCommands(scli, &config)
 	cli.AddConfigCommands(scli, &config)
+	cli.AddConsumerCommands(scli, &config)
 
 	scli.MustParseWithUsage(os.Args[1:])
 }
@@ -70,6 +79,42 @@ func getVersion() string {
 	return nfo.Main.Version
 }
 
+// captureTerminalState saves the terminal mode before any prompt or TUI
+// switches it to raw mode. Returns nil when stdin isn't a terminal.
+func captureTerminalState() *term.State {
+	fd := int(os.Stdin.Fd())
+	if !term.IsTerminal(fd) {
+		return nil
+	}
+
+	state, err := term.GetState(fd)
+	if err != nil {
+		log.Println("Failed to capture terminal state:", err)
+		return nil
+	}
+	return state
+}
+
+func recoverFromPanic(state *term.State) {
+	r := recover()
+	if r == nil {
+		return
+	}
+	stack := debug.Stack()
+
+	if state != nil {
+		term.Restore(int(os.Stdin.Fd()), state)
+	}
+	if term.IsTerminal(int(os.Stdout.Fd())) {
+		// Leave the alternate screen and show the cursor
+		fmt.Print("\x1b[?1049l\x1b[?25h")
+	}
+
+	log.Printf("Panic: %v\n%s", r, stack)
+	fmt.Fprintf(os.Stderr, "Sequin CLI crashed: %v\n\n%s\nSee %s for details.\n", r, stack, constants.LogFilePath)
+	os.Exit(2)
+}
+
 func trimLogFile(file *os.File, maxLines int) error {
 	// Read all lines
 	scanner := bufio.NewScanner(file)